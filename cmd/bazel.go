@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/gabotechs/dep-tree/internal/bazel"
+	"github.com/spf13/cobra"
+)
+
+var bazelDryRun bool
+var bazelCheck bool
+var bazelFix bool
+var bazelLabels string
+var bazelInferMissingTargets bool
+
+// BazelCmd walks the resolved dependency graph and keeps each directory's
+// BUILD.bazel `deps` attribute in sync with it.
+func BazelCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "bazel [files...]",
+		GroupID: checkGroupId,
+		Short:   "write/update BUILD.bazel deps for the given files' dependency graph",
+		Example: `$ dep-tree bazel src/**/*.py --fix
+$ dep-tree bazel src/**/*.py --check`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			files, err := filesFromArgs(args, cfg)
+			if err != nil {
+				return err
+			}
+			return runBazel(files)
+		},
+	}
+	cmd.Flags().BoolVar(&bazelDryRun, "dry-run", false, "print a unified diff of the proposed BUILD.bazel edits without writing them. (default false)")
+	cmd.Flags().BoolVar(&bazelCheck, "check", false, "exit non-zero if any BUILD.bazel's deps are stale, without writing them. (default false)")
+	cmd.Flags().BoolVar(&bazelFix, "fix", false, "rewrite BUILD.bazel files in place. (default false)")
+	cmd.Flags().StringVar(&bazelLabels, "labels", "bazel-labels.yml", "path to a module path -> Bazel label mapping file.")
+	cmd.Flags().BoolVar(&bazelInferMissingTargets, "infer-missing-targets", false, "for imports missing from --labels, guess a label from the module path's own directory structure instead of reporting them as unresolved. (default false)")
+	return cmd
+}
+
+func runBazel(files []string) error {
+	if !bazelDryRun && !bazelCheck && !bazelFix {
+		return errors.New("one of --dry-run, --check or --fix must be provided")
+	}
+
+	mapLoader, err := bazel.LoadTargetLoaderFromYaml(bazelLabels)
+	if err != nil {
+		return err
+	}
+	var loader bazel.TargetLoader = mapLoader
+	if bazelInferMissingTargets {
+		loader = bazel.ChainTargetLoader{mapLoader, bazel.DirTargetLoader{}}
+	}
+
+	sourceFiles := make([]bazel.SourceFile, 0, len(files))
+	for _, file := range files {
+		lang, ok := bazel.LanguageOf(file)
+		if !ok {
+			continue
+		}
+		imports, err := bazel.ScanImports(file, lang)
+		if err != nil {
+			return err
+		}
+		sourceFiles = append(sourceFiles, bazel.SourceFile{Path: file, Language: lang, Imports: imports})
+	}
+
+	results, err := bazel.Apply(bazel.Plan(sourceFiles, loader))
+	if err != nil {
+		return err
+	}
+
+	warnUnresolved(results)
+
+	switch {
+	case bazelCheck:
+		var stale []string
+		var unresolved []string
+		for _, r := range results {
+			if r.Changed {
+				stale = append(stale, r.BuildFile)
+			}
+			if len(r.Unresolved) > 0 {
+				unresolved = append(unresolved, r.BuildFile)
+			}
+		}
+		// Checked first: an unresolved import means deps are incomplete
+		// regardless of whether the (necessarily partial) deps list we did
+		// compute happens to already match what's on disk.
+		if len(unresolved) > 0 {
+			return fmt.Errorf("%d BUILD.bazel file(s) have imports that couldn't be resolved to a label: %v", len(unresolved), unresolved)
+		}
+		if len(stale) > 0 {
+			return fmt.Errorf("%d BUILD.bazel file(s) have stale deps: %v", len(stale), stale)
+		}
+		return nil
+	case bazelDryRun:
+		for _, r := range results {
+			if diff := bazel.UnifiedDiff(r.BuildFile, r.Before, r.After); diff != "" {
+				fmt.Println(diff)
+			}
+		}
+		return nil
+	default: // --fix
+		return bazel.Write(results)
+	}
+}
+
+// warnUnresolved prints a warning for every import Plan couldn't resolve
+// to a Bazel label, so an incomplete bazel-labels.yml mapping shows up as
+// noise instead of a silently truncated deps list.
+func warnUnresolved(results []bazel.Result) {
+	for _, r := range results {
+		for _, imp := range r.Unresolved {
+			fmt.Fprintf(os.Stderr, "warning: %s: could not resolve import %q to a Bazel label, left out of deps\n", r.BuildFile, imp)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func withBazelFlags(t *testing.T, dryRun, check, fix bool, labels string, inferMissingTargets bool) {
+	t.Helper()
+	prevDryRun, prevCheck, prevFix, prevLabels, prevInfer := bazelDryRun, bazelCheck, bazelFix, bazelLabels, bazelInferMissingTargets
+	bazelDryRun, bazelCheck, bazelFix, bazelLabels, bazelInferMissingTargets = dryRun, check, fix, labels, inferMissingTargets
+	t.Cleanup(func() {
+		bazelDryRun, bazelCheck, bazelFix, bazelLabels, bazelInferMissingTargets = prevDryRun, prevCheck, prevFix, prevLabels, prevInfer
+	})
+}
+
+func TestRunBazel_CheckFailsOnUnresolvedImports(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	file := writeTempFile(t, dir, "src/foo.py")
+	a.NoError(os.WriteFile(file, []byte("import pkg.unmapped\n"), os.ModePerm))
+
+	labels := filepath.Join(dir, "bazel-labels.yml")
+	a.NoError(os.WriteFile(labels, []byte("{}\n"), os.ModePerm))
+	withBazelFlags(t, false, true, false, labels, false)
+
+	err := runBazel([]string{file})
+	a.Error(err)
+	a.Contains(err.Error(), "couldn't be resolved")
+}
+
+func TestRunBazel_CheckPassesWhenDepsAreUpToDate(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	file := writeTempFile(t, dir, "src/foo.py")
+	a.NoError(os.WriteFile(file, []byte("import pkg.helpers\n"), os.ModePerm))
+
+	labels := filepath.Join(dir, "bazel-labels.yml")
+	a.NoError(os.WriteFile(labels, []byte("pkg.helpers: //pkg:helpers\n"), os.ModePerm))
+	withBazelFlags(t, false, false, true, labels, false)
+	a.NoError(runBazel([]string{file}))
+
+	withBazelFlags(t, false, true, false, labels, false)
+	a.NoError(runBazel([]string{file}))
+}
+
+func TestRunBazel_InferMissingTargetsFallsBackToDirTargetLoader(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	file := writeTempFile(t, dir, "src/foo.py")
+	a.NoError(os.WriteFile(file, []byte("import pkg.unmapped\n"), os.ModePerm))
+
+	labels := filepath.Join(dir, "bazel-labels.yml")
+	a.NoError(os.WriteFile(labels, []byte("{}\n"), os.ModePerm))
+	withBazelFlags(t, false, false, true, labels, true)
+	a.NoError(runBazel([]string{file}))
+
+	withBazelFlags(t, false, true, false, labels, true)
+	a.NoError(runBazel([]string{file}), "--infer-missing-targets should resolve pkg.unmapped via the directory-derived default")
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gabotechs/dep-tree/internal/config"
+	"github.com/gabotechs/dep-tree/internal/utils"
+)
+
+// recursiveSuffix is the Go-style `...` recursive package selector, e.g.
+// `src/...`, meaning "every file under src, recursively".
+const recursiveSuffix = "/..."
+
+// isPattern reports whether arg needs to be expanded against the
+// filesystem rather than treated as a literal, already-existing file path.
+func isPattern(arg string) bool {
+	return strings.HasSuffix(arg, recursiveSuffix) || strings.ContainsAny(arg, "*?[")
+}
+
+// expandPattern resolves a single glob pattern or `...` recursive selector
+// into the absolute paths of every file it matches.
+func expandPattern(pattern string) ([]string, error) {
+	if !isPattern(pattern) {
+		abs, err := filepath.Abs(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if !utils.FileExists(abs) {
+			return nil, nil
+		}
+		return []string{abs}, nil
+	}
+
+	recursive := strings.HasSuffix(pattern, recursiveSuffix)
+	root := globRoot(pattern)
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []string
+	walkErr := filepath.WalkDir(absRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			// Don't follow symlinked directories: they can point back up the
+			// tree and turn a walk into an infinite loop.
+			if path != absRoot && isSymlink(path) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if isSymlink(path) && !resolvesToRegularFile(path) {
+			return nil
+		}
+
+		if recursive {
+			matches = append(matches, path)
+			return nil
+		}
+		rel, err := filepath.Rel(absRoot, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		matched, err := utils.GlobstarMatch(pattern, filepath.ToSlash(filepath.Join(root, rel)))
+		if err != nil {
+			return err
+		}
+		if matched {
+			matches = append(matches, path)
+		}
+		return nil
+	})
+	if walkErr != nil {
+		return nil, walkErr
+	}
+	return matches, nil
+}
+
+// globRoot returns the longest directory prefix of pattern that doesn't
+// contain any wildcard, which is where the filesystem walk can start from.
+func globRoot(pattern string) string {
+	pattern = strings.TrimSuffix(pattern, recursiveSuffix)
+	segments := strings.Split(pattern, "/")
+	var root []string
+	for _, segment := range segments {
+		if strings.ContainsAny(segment, "*?[") {
+			break
+		}
+		root = append(root, segment)
+	}
+	if len(root) == 0 {
+		return "."
+	}
+	return strings.Join(root, "/")
+}
+
+func isSymlink(path string) bool {
+	info, err := os.Lstat(path)
+	return err == nil && info.Mode()&os.ModeSymlink != 0
+}
+
+func resolvesToRegularFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// filesFromArgs expands args into a de-duplicated list of absolute file
+// paths. Each arg can be a literal file path, a glob pattern (`*`, `?`,
+// `[...]`, or the globstar-aware `**`), or a `...`-suffixed recursive
+// selector such as `src/...`. Prefixing an arg with `!` excludes whatever
+// it matches from the final result, and cfg.Exclude is honored the same
+// way the rest of dep-tree already does.
+func filesFromArgs(args []string, cfg *config.Config) ([]string, error) {
+	var positive, negative []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "!") {
+			pattern := strings.TrimPrefix(arg, "!")
+			if !filepath.IsAbs(pattern) {
+				if cwd, err := os.Getwd(); err == nil {
+					pattern = filepath.ToSlash(filepath.Join(cwd, pattern))
+				}
+			}
+			negative = append(negative, pattern)
+		} else {
+			positive = append(positive, arg)
+		}
+	}
+
+	seen := map[string]bool{}
+	var result []string
+	var errs []error
+	for _, pattern := range positive {
+		matches, err := expandPattern(pattern)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if len(matches) == 0 {
+			errs = append(errs, fmt.Errorf("pattern %q did not match any files", pattern))
+			continue
+		}
+		for _, file := range matches {
+			if seen[file] {
+				continue
+			}
+			excluded, err := isExcluded(file, negative, cfg)
+			if err != nil {
+				return nil, err
+			}
+			if excluded {
+				continue
+			}
+			seen[file] = true
+			result = append(result, file)
+		}
+	}
+
+	if len(result) == 0 {
+		if len(errs) == 1 {
+			return result, errs[0]
+		}
+		return result, errors.New("no valid files where provided")
+	}
+	return result, nil
+}
+
+func isExcluded(file string, negative []string, cfg *config.Config) (bool, error) {
+	for _, pattern := range append(append([]string{}, negative...), cfg.Exclude...) {
+		matched, err := utils.GlobstarMatch(pattern, filepath.ToSlash(file))
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gabotechs/dep-tree/internal/config"
+)
+
+func writeTempFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+	require.NoError(t, os.WriteFile(path, []byte("content"), os.ModePerm))
+	return path
+}
+
+func withCwd(t *testing.T, dir string) {
+	t.Helper()
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(cwd) })
+}
+
+func TestFilesFromArgs_NegativePattern(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	kept := writeTempFile(t, dir, "src/a.py")
+	writeTempFile(t, dir, "src/a.test.py")
+	withCwd(t, dir)
+
+	files, err := filesFromArgs([]string{"src/*.py", "!src/*.test.py"}, &config.Config{})
+	a.NoError(err)
+	a.Equal([]string{kept}, files)
+}
+
+func TestFilesFromArgs_SymlinkedFileIsIncludedOnce(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	target := writeTempFile(t, dir, "real/a.py")
+	linkPath := filepath.Join(dir, "real", "link.py")
+	a.NoError(os.Symlink(target, linkPath))
+	withCwd(t, dir)
+
+	files, err := filesFromArgs([]string{"real/...", "real/...", linkPath}, &config.Config{})
+	a.NoError(err)
+	sort.Strings(files)
+	a.Equal([]string{target, linkPath}, files)
+}
+
+func TestFilesFromArgs_SymlinkedDirectoryIsNotFollowed(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	writeTempFile(t, dir, "real/a.py")
+	a.NoError(os.Symlink(filepath.Join(dir, "real"), filepath.Join(dir, "real", "loop")))
+	withCwd(t, dir)
+
+	files, err := filesFromArgs([]string{"real/..."}, &config.Config{})
+	a.NoError(err)
+	a.Len(files, 1)
+}
+
+func TestFilesFromArgs_NoMatches(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	withCwd(t, dir)
+
+	_, err := filesFromArgs([]string{"src/*.py"}, &config.Config{})
+	a.Error(err)
+}
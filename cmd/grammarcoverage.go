@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/gabotechs/dep-tree/internal/coverage"
+	"github.com/spf13/cobra"
+)
+
+// GrammarCoverageCmd runs `go test` with grammar coverage instrumentation
+// turned on, and prints what changed in coverage.json relative to the last
+// time it was generated.
+func GrammarCoverageCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "grammar-coverage [packages...]",
+		Short: "run go test with grammar coverage instrumentation and print the delta",
+		Example: `$ dep-tree grammar-coverage ./...
+$ dep-tree grammar-coverage ./internal/python/...`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				args = []string{"./..."}
+			}
+			return runGrammarCoverage(args)
+		},
+	}
+}
+
+func runGrammarCoverage(packages []string) error {
+	const reportPath = "coverage.json"
+
+	dirs, err := packageDirs(packages)
+	if err != nil {
+		return err
+	}
+
+	before := findReport(dirs, reportPath)
+
+	goTestArgs := append([]string{"test"}, packages...)
+	testCmd := exec.Command("go", goTestArgs...)
+	testCmd.Env = append(os.Environ(), "DEP_TREE_GRAMMAR_COVERAGE=1")
+	testCmd.Stdout = os.Stdout
+	testCmd.Stderr = os.Stderr
+	if err := testCmd.Run(); err != nil {
+		return fmt.Errorf("go test failed: %w", err)
+	}
+
+	after := findReport(dirs, reportPath)
+
+	diff := coverage.CompareReports(before, after)
+	fmt.Printf("newly covered: %v\n", diff.NewlyCovered)
+	fmt.Printf("newly never-hit: %v\n", diff.NewlyNeverHit)
+	fmt.Printf("count deltas: %v\n", diff.CountDeltas)
+	return nil
+}
+
+// packageDirs resolves package patterns like "./..." into the directories
+// `go test` actually runs each package's binary in, since that's where a
+// package's TestMain writes its coverage.json.
+func packageDirs(packages []string) ([]string, error) {
+	listArgs := append([]string{"list", "-f", "{{.Dir}}"}, packages...)
+	out, err := exec.Command("go", listArgs...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list failed: %w", err)
+	}
+	var dirs []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			dirs = append(dirs, line)
+		}
+	}
+	return dirs, nil
+}
+
+// findReport reads the coverage.json every instrumented package wrote into
+// its own directory and merges them into one report. Packages that weren't
+// instrumented (no grammar coverage TestMain) simply have no coverage.json
+// and are skipped.
+func findReport(dirs []string, reportPath string) coverage.Report {
+	var reports []coverage.Report
+	for _, dir := range dirs {
+		if report, err := coverage.ReadReport(filepath.Join(dir, reportPath)); err == nil {
+			reports = append(reports, report)
+		}
+	}
+	return coverage.MergeReports(reports...)
+}
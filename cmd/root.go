@@ -6,9 +6,11 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/gabotechs/dep-tree/internal/classify"
 	"github.com/gabotechs/dep-tree/internal/config"
 	"github.com/gabotechs/dep-tree/internal/dart"
 	"github.com/gabotechs/dep-tree/internal/dummy"
+	"github.com/gabotechs/dep-tree/internal/golang"
 	"github.com/gabotechs/dep-tree/internal/js"
 	"github.com/gabotechs/dep-tree/internal/language"
 	"github.com/gabotechs/dep-tree/internal/python"
@@ -27,6 +29,12 @@ var unwrapExports bool
 var jsTsConfigPaths bool
 var jsWorkspaces bool
 var pythonExcludeConditionalImports bool
+var pythonExcludeTypeChecking bool
+var pythonExcludeLazyImports bool
+var goExcludeStdlib bool
+var goExcludeThirdParty bool
+var goIncludeTests bool
+var classifyContent bool
 var exclude []string
 
 var root *cobra.Command
@@ -63,6 +71,8 @@ $ dep-tree check`,
 		TreeCmd(),
 		CheckCmd(),
 		ConfigCmd(),
+		BazelCmd(),
+		GrammarCoverageCmd(),
 	)
 
 	root.AddGroup(&cobra.Group{ID: renderGroupId, Title: "Visualize your dependencies graphically"})
@@ -76,6 +86,12 @@ $ dep-tree check`,
 	root.PersistentFlags().BoolVar(&jsTsConfigPaths, "js-tsconfig-paths", true, "follow the tsconfig.json paths while resolving imports.")
 	root.PersistentFlags().BoolVar(&jsWorkspaces, "js-workspaces", true, "take the workspaces attribute in the root package.json into account for resolving paths.")
 	root.PersistentFlags().BoolVar(&pythonExcludeConditionalImports, "python-exclude-conditional-imports", false, "exclude imports wrapped inside if or try statements. (default false)")
+	root.PersistentFlags().BoolVar(&pythonExcludeTypeChecking, "python-exclude-type-checking", false, "exclude imports that only run inside an `if TYPE_CHECKING:` block. (default false)")
+	root.PersistentFlags().BoolVar(&pythonExcludeLazyImports, "python-exclude-lazy-imports", false, "exclude imports that are lazily done inside a function body. (default false)")
+	root.PersistentFlags().BoolVar(&goExcludeStdlib, "go-exclude-stdlib", false, "exclude standard library packages from the graph. (default false)")
+	root.PersistentFlags().BoolVar(&goExcludeThirdParty, "go-exclude-third-party", false, "exclude packages outside of the current module from the graph. (default false)")
+	root.PersistentFlags().BoolVar(&goIncludeTests, "go-include-tests", false, "also parse _test.go files. (default false)")
+	root.PersistentFlags().BoolVar(&classifyContent, "classify-content", true, "fall back to content-based language detection for files with a missing or ambiguous extension. (default true)")
 
 	switch {
 	case len(args) > 0 && utils.InArray(args[0], []string{"help", "completion", "-v", "--version", "-h", "--help"}):
@@ -94,56 +110,59 @@ $ dep-tree check`,
 	return root
 }
 
+// extensionLanguages maps each recognized extension set to the language
+// name used across inferLang's scoring and the final switch.
+var extensionLanguages = []struct {
+	name string
+	exts []string
+}{
+	{"js", js.Extensions},
+	{"rust", rust.Extensions},
+	{"python", python.Extensions},
+	{"dummy", dummy.Extensions},
+	{"dart", dart.Extensions},
+	{"golang", golang.Extensions},
+}
+
 func inferLang(files []string, cfg *config.Config) (language.Language, error) {
-	score := struct {
-		js     int
-		python int
-		rust   int
-		dummy  int
-		dart   int
-	}{}
-	top := struct {
-		lang string
-		v    int
-	}{}
+	score := map[string]float64{}
+	var unmatched []string
 	for _, file := range files {
-		switch {
-		case utils.EndsWith(file, js.Extensions):
-			score.js += 1
-			if score.js > top.v {
-				top.v = score.js
-				top.lang = "js"
-			}
-		case utils.EndsWith(file, rust.Extensions):
-			score.rust += 1
-			if score.rust > top.v {
-				top.v = score.rust
-				top.lang = "rust"
+		matched := false
+		for _, candidate := range extensionLanguages {
+			if utils.EndsWith(file, candidate.exts) {
+				score[candidate.name] += 1
+				matched = true
+				break
 			}
-		case utils.EndsWith(file, python.Extensions):
-			score.python += 1
-			if score.python > top.v {
-				top.v = score.python
-				top.lang = "python"
-			}
-		case utils.EndsWith(file, dummy.Extensions):
-			score.dummy += 1
-			if score.dummy > top.v {
-				top.v = score.dummy
-				top.lang = "dummy"
+		}
+		if !matched {
+			unmatched = append(unmatched, file)
+		}
+	}
+
+	// Content classification only decides files extension matching couldn't:
+	// each unmatched file casts a single vote, the same weight an
+	// extension match carries, so a strong extension-based majority can't
+	// get outvoted by one or two ambiguous files.
+	if classifyContent && len(unmatched) > 0 {
+		classifier := classify.NewDefaultClassifier()
+		for _, file := range unmatched {
+			content, err := os.ReadFile(file)
+			if err != nil {
+				continue
 			}
-		case utils.EndsWith(file, dart.Extensions):
-			score.dart += 1
-			if score.dart > top.v {
-				top.v = score.dart
-				top.lang = "dart"
+			if lang, ok := classify.Argmax(classifier.Classify(content, score)); ok {
+				score[lang] += 1
 			}
 		}
 	}
-	if top.lang == "" {
+
+	lang, ok := classify.Argmax(score)
+	if !ok {
 		return nil, errors.New("at least one file must be provided")
 	}
-	switch top.lang {
+	switch lang {
 	case "js":
 		return js.MakeJsLanguage(&cfg.Js)
 	case "rust":
@@ -152,6 +171,12 @@ func inferLang(files []string, cfg *config.Config) (language.Language, error) {
 		return python.MakePythonLanguage(&cfg.Python)
 	case "dart":
 		return &dart.Language{}, nil
+	case "golang":
+		return golang.MakeGoLanguage(&golang.Config{
+			ExcludeStdlib:     goExcludeStdlib,
+			ExcludeThirdParty: goExcludeThirdParty,
+			IncludeTests:      goIncludeTests,
+		}, files)
 	case "dummy":
 		return &dummy.Language{}, nil
 	default:
@@ -159,29 +184,6 @@ func inferLang(files []string, cfg *config.Config) (language.Language, error) {
 	}
 }
 
-func filesFromArgs(args []string) ([]string, error) {
-	var result []string
-	var errs []error
-	for _, arg := range args {
-		abs, err := filepath.Abs(arg)
-		if err != nil {
-			errs = append(errs, err)
-		} else if !utils.FileExists(abs) {
-			errs = append(errs, fmt.Errorf("file %s does not exist", arg))
-		} else {
-			result = append(result, abs)
-		}
-	}
-	if len(result) == 0 {
-		if len(errs) == 1 {
-			return result, errs[0]
-		}
-		return result, errors.New("no valid files where provided")
-	}
-
-	return result, nil
-}
-
 func loadConfig() (*config.Config, error) {
 	cfg, err := config.ParseConfig(configPath)
 	if err != nil {
@@ -199,6 +201,12 @@ func loadConfig() (*config.Config, error) {
 	if root.PersistentFlags().Changed("python-exclude-conditional-imports") {
 		cfg.Python.ExcludeConditionalImports = pythonExcludeConditionalImports
 	}
+	if root.PersistentFlags().Changed("python-exclude-type-checking") {
+		cfg.Python.ExcludeTypeChecking = pythonExcludeTypeChecking
+	}
+	if root.PersistentFlags().Changed("python-exclude-lazy-imports") {
+		cfg.Python.ExcludeLazyImports = pythonExcludeLazyImports
+	}
 	// NOTE: hard-enable this for now, as they don't produce a very good output.
 	cfg.Python.IgnoreFromImportsAsExports = true
 	cfg.Python.IgnoreDirectoryImports = true
@@ -0,0 +1,131 @@
+package bazel
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// kindForLanguage maps a dep-tree language name to the Bazel rule kind that
+// owns source files written in it.
+var kindForLanguage = map[string]string{
+	"python": "py_library",
+	"js":     "js_library",
+	"ts":     "ts_library",
+	"rust":   "rust_library",
+}
+
+// SourceFile is one resolved node out of dep-tree's dependency graph: a
+// file, the language it was parsed as, and the module paths it imports.
+type SourceFile struct {
+	Path     string
+	Language string
+	Imports  []string
+}
+
+// Edit is a single proposed change to a BUILD.bazel file: its path, the
+// rule it belongs to, and the deps list it should end up with. Unresolved
+// holds the module paths that loader couldn't resolve to a label, so
+// callers can surface them instead of silently ending up with a truncated
+// deps list.
+type Edit struct {
+	BuildFile  string
+	Kind       string
+	Name       string
+	Deps       []string
+	Unresolved []string
+}
+
+// Plan groups SourceFile entries by directory and resolves their imports
+// into Bazel labels via loader, producing one Edit per directory/kind pair
+// that needs a `deps` attribute.
+func Plan(files []SourceFile, loader TargetLoader) []Edit {
+	type key struct {
+		dir  string
+		kind string
+	}
+	depSets := map[key]map[string]struct{}{}
+	unresolvedSets := map[key]map[string]struct{}{}
+	names := map[key]string{}
+
+	for _, f := range files {
+		kind, ok := kindForLanguage[f.Language]
+		if !ok {
+			continue
+		}
+		dir := filepath.Dir(f.Path)
+		k := key{dir, kind}
+		if depSets[k] == nil {
+			depSets[k] = map[string]struct{}{}
+			unresolvedSets[k] = map[string]struct{}{}
+			names[k] = filepath.Base(dir)
+		}
+		for _, imp := range f.Imports {
+			if label, ok := loader.Resolve(imp); ok {
+				depSets[k][label] = struct{}{}
+			} else {
+				unresolvedSets[k][imp] = struct{}{}
+			}
+		}
+	}
+
+	edits := make([]Edit, 0, len(depSets))
+	for k, set := range depSets {
+		deps := make([]string, 0, len(set))
+		for dep := range set {
+			deps = append(deps, dep)
+		}
+		unresolved := make([]string, 0, len(unresolvedSets[k]))
+		for imp := range unresolvedSets[k] {
+			unresolved = append(unresolved, imp)
+		}
+		sort.Strings(unresolved)
+		edits = append(edits, Edit{
+			BuildFile:  filepath.Join(k.dir, "BUILD.bazel"),
+			Kind:       k.kind,
+			Name:       names[k],
+			Deps:       deps,
+			Unresolved: unresolved,
+		})
+	}
+	return edits
+}
+
+// Apply renders every edit against its BUILD.bazel file on disk (treating a
+// missing file as empty) and returns, per file, the resulting content plus
+// whether it differs from what's there today.
+type Result struct {
+	Edit
+	Before  string
+	After   string
+	Changed bool
+}
+
+func Apply(edits []Edit) ([]Result, error) {
+	results := make([]Result, 0, len(edits))
+	for _, edit := range edits {
+		before := ""
+		if content, err := os.ReadFile(edit.BuildFile); err == nil {
+			before = string(content)
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("could not read %s: %w", edit.BuildFile, err)
+		}
+		after := ParseBuildFile(before).SetDeps(edit.Kind, edit.Name, edit.Deps)
+		results = append(results, Result{Edit: edit, Before: before, After: after, Changed: before != after})
+	}
+	return results, nil
+}
+
+// Write persists every changed result to disk.
+func Write(results []Result) error {
+	for _, r := range results {
+		if !r.Changed {
+			continue
+		}
+		if err := os.WriteFile(r.BuildFile, []byte(r.After), 0o644); err != nil {
+			return fmt.Errorf("could not write %s: %w", r.BuildFile, err)
+		}
+	}
+	return nil
+}
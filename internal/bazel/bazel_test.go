@@ -0,0 +1,46 @@
+package bazel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlan_ResolvesDepsAndSurfacesUnresolvedImports(t *testing.T) {
+	a := require.New(t)
+
+	loader := &MapTargetLoader{Labels: map[string]string{
+		"pkg.helpers": "//pkg:helpers",
+	}}
+	files := []SourceFile{
+		{Path: "src/foo.py", Language: "python", Imports: []string{"pkg.helpers", "pkg.unmapped"}},
+		{Path: "src/bar.py", Language: "python", Imports: []string{"pkg.helpers"}},
+	}
+
+	edits := Plan(files, loader)
+	a.Len(edits, 1)
+	edit := edits[0]
+	a.Equal("src/BUILD.bazel", edit.BuildFile)
+	a.Equal("py_library", edit.Kind)
+	a.Equal([]string{"//pkg:helpers"}, edit.Deps)
+	a.Equal([]string{"pkg.unmapped"}, edit.Unresolved)
+}
+
+func TestPlan_SkipsUnsupportedLanguages(t *testing.T) {
+	a := require.New(t)
+
+	edits := Plan([]SourceFile{{Path: "src/foo.go", Language: "golang", Imports: []string{"pkg.helpers"}}}, &MapTargetLoader{})
+	a.Empty(edits)
+}
+
+func TestApply_ReportsChanged(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+
+	edit := Edit{BuildFile: dir + "/BUILD.bazel", Kind: "py_library", Name: "foo", Deps: []string{"//pkg:helpers"}}
+	results, err := Apply([]Edit{edit})
+	a.NoError(err)
+	a.Len(results, 1)
+	a.True(results[0].Changed)
+	a.Contains(results[0].After, "//pkg:helpers")
+}
@@ -0,0 +1,94 @@
+package bazel
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnifiedDiff renders a unified diff between before and after, labeled
+// with path, as a single hunk spanning the whole file with every unchanged
+// line kept as context. It's deliberately simple: a line-based
+// longest-common-subsequence diff is enough to show proposed `deps` edits
+// without pulling in an external diff library.
+func UnifiedDiff(path, before, after string) string {
+	if before == after {
+		return ""
+	}
+	a := strings.Split(before, "\n")
+	b := strings.Split(after, "\n")
+	ops := lcsDiff(a, b)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- a/%s\n", path)
+	fmt.Fprintf(&sb, "+++ b/%s\n", path)
+	fmt.Fprintf(&sb, "@@ -1,%d +1,%d @@\n", len(a), len(b))
+	for _, op := range ops {
+		switch op.kind {
+		case diffEqual:
+			fmt.Fprintf(&sb, " %s\n", op.line)
+		case diffRemove:
+			fmt.Fprintf(&sb, "-%s\n", op.line)
+		case diffAdd:
+			fmt.Fprintf(&sb, "+%s\n", op.line)
+		}
+	}
+	return sb.String()
+}
+
+type diffKind int
+
+const (
+	diffEqual diffKind = iota
+	diffAdd
+	diffRemove
+)
+
+type diffOp struct {
+	kind diffKind
+	line string
+}
+
+// lcsDiff computes a line diff via a straightforward O(n*m) longest-common-
+// subsequence table, which is plenty for BUILD.bazel-sized files.
+func lcsDiff(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	table := make([][]int, n+1)
+	for i := range table {
+		table[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else if table[i+1][j] >= table[i][j+1] {
+				table[i][j] = table[i+1][j]
+			} else {
+				table[i][j] = table[i][j+1]
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{diffEqual, a[i]})
+			i++
+			j++
+		case table[i+1][j] >= table[i][j+1]:
+			ops = append(ops, diffOp{diffRemove, a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{diffAdd, b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{diffRemove, a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{diffAdd, b[j]})
+	}
+	return ops
+}
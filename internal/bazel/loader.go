@@ -0,0 +1,84 @@
+package bazel
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TargetLoader resolves a module path, as it shows up in an import (e.g.
+// "foo.bar.baz" or "@scope/pkg"), into the Bazel label that owns it (e.g.
+// "//foo/bar:baz"). Users that don't follow a 1:1 directory-to-package
+// layout can plug in their own TargetLoader instead of relying on
+// DirTargetLoader, the directory-derived default.
+type TargetLoader interface {
+	Resolve(modulePath string) (label string, ok bool)
+}
+
+// ChainTargetLoader tries each TargetLoader in order and returns the first
+// one that resolves modulePath. It's how an explicit mapping (e.g. a
+// MapTargetLoader loaded from bazel-labels.yml) gets layered in front of
+// DirTargetLoader's directory-derived default, when a caller opts into
+// that fallback (see the bazel command's --infer-missing-targets flag)
+// rather than wanting a missing mapping entry reported as unresolved.
+type ChainTargetLoader []TargetLoader
+
+func (c ChainTargetLoader) Resolve(modulePath string) (string, bool) {
+	for _, loader := range c {
+		if label, ok := loader.Resolve(modulePath); ok {
+			return label, true
+		}
+	}
+	return "", false
+}
+
+// DirTargetLoader is the directory-derived default TargetLoader: it treats
+// every dot-separated segment of modulePath but the last as a directory
+// component, and the last as the target name within that directory, so
+// "foo.bar.baz" resolves to "//foo/bar:baz". It only applies to plain
+// dotted module paths; anything else (scoped JS packages, third-party
+// modules, ...) should go in a MapTargetLoader mapping layered in front of
+// it via ChainTargetLoader instead.
+type DirTargetLoader struct{}
+
+func (DirTargetLoader) Resolve(modulePath string) (string, bool) {
+	if modulePath == "" || strings.ContainsAny(modulePath, "/@") {
+		return "", false
+	}
+	segments := strings.Split(modulePath, ".")
+	if len(segments) < 2 {
+		return "", false
+	}
+	dir := strings.Join(segments[:len(segments)-1], "/")
+	return fmt.Sprintf("//%s:%s", dir, segments[len(segments)-1]), true
+}
+
+// MapTargetLoader resolves labels out of a static module-path -> label
+// mapping, such as the one loaded from a bazel-labels.yml file.
+type MapTargetLoader struct {
+	Labels map[string]string
+}
+
+// LoadTargetLoaderFromYaml reads a bazel-labels.yml file holding a flat
+// module path -> Bazel label mapping, e.g.:
+//
+//	foo.bar.baz: //foo/bar:baz
+//	"@scope/pkg": //third_party/scope:pkg
+func LoadTargetLoaderFromYaml(path string) (*MapTargetLoader, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read target mapping file %s: %w", path, err)
+	}
+	labels := map[string]string{}
+	if err := yaml.Unmarshal(content, &labels); err != nil {
+		return nil, fmt.Errorf("could not parse target mapping file %s: %w", path, err)
+	}
+	return &MapTargetLoader{Labels: labels}, nil
+}
+
+func (l *MapTargetLoader) Resolve(modulePath string) (string, bool) {
+	label, ok := l.Labels[modulePath]
+	return label, ok
+}
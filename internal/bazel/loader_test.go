@@ -0,0 +1,43 @@
+package bazel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirTargetLoader(t *testing.T) {
+	a := require.New(t)
+
+	label, ok := DirTargetLoader{}.Resolve("foo.bar.baz")
+	a.True(ok)
+	a.Equal("//foo/bar:baz", label)
+
+	_, ok = DirTargetLoader{}.Resolve("baz")
+	a.False(ok, "a single segment has no directory to derive a label from")
+
+	_, ok = DirTargetLoader{}.Resolve("@scope/pkg")
+	a.False(ok, "scoped/path-like module paths aren't dot-separated packages")
+
+	_, ok = DirTargetLoader{}.Resolve("")
+	a.False(ok)
+}
+
+func TestChainTargetLoader_PrefersEarlierLoader(t *testing.T) {
+	a := require.New(t)
+	chain := ChainTargetLoader{
+		&MapTargetLoader{Labels: map[string]string{"foo.bar.baz": "//custom:target"}},
+		DirTargetLoader{},
+	}
+
+	label, ok := chain.Resolve("foo.bar.baz")
+	a.True(ok)
+	a.Equal("//custom:target", label)
+
+	label, ok = chain.Resolve("foo.qux")
+	a.True(ok, "falls through to the directory-derived default")
+	a.Equal("//foo:qux", label)
+
+	_, ok = chain.Resolve("unmapped")
+	a.False(ok)
+}
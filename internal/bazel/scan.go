@@ -0,0 +1,62 @@
+package bazel
+
+import (
+	"os"
+	"regexp"
+)
+
+// languageExtensions mirrors the extension sets of the language packages
+// this command targets, scoped down to what's needed to pick a rule kind
+// and a lightweight import scan for each file.
+var languageExtensions = map[string][]string{
+	"python": {".py"},
+	"js":     {".js", ".jsx"},
+	"ts":     {".ts", ".tsx"},
+	"rust":   {".rs"},
+}
+
+var importPatterns = map[string]*regexp.Regexp{
+	"python": regexp.MustCompile(`(?m)^\s*(?:from\s+([\w.]+)\s+import|import\s+([\w.]+))`),
+	"js":     regexp.MustCompile(`(?m)(?:import[^'"]*['"]([^'"]+)['"]|require\(['"]([^'"]+)['"]\))`),
+	"ts":     regexp.MustCompile(`(?m)(?:import[^'"]*['"]([^'"]+)['"]|require\(['"]([^'"]+)['"]\))`),
+	"rust":   regexp.MustCompile(`(?m)^\s*use\s+([\w:]+)`),
+}
+
+// LanguageOf returns the bazel target language for a file path based on its
+// extension, and false if none of the supported languages claim it.
+func LanguageOf(path string) (string, bool) {
+	for lang, exts := range languageExtensions {
+		for _, ext := range exts {
+			if len(path) > len(ext) && path[len(path)-len(ext):] == ext {
+				return lang, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ScanImports does a regex-based best-effort extraction of the module
+// paths a source file imports, just enough to resolve them into Bazel
+// labels via a TargetLoader. It intentionally doesn't re-implement each
+// language's full import grammar: that lives in the language packages
+// proper, and this command only needs the raw module path strings.
+func ScanImports(path, language string) ([]string, error) {
+	pattern, ok := importPatterns[language]
+	if !ok {
+		return nil, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var imports []string
+	for _, match := range pattern.FindAllStringSubmatch(string(content), -1) {
+		for _, group := range match[1:] {
+			if group != "" {
+				imports = append(imports, group)
+				break
+			}
+		}
+	}
+	return imports, nil
+}
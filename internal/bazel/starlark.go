@@ -0,0 +1,156 @@
+package bazel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Rule is a single top-level `kind(name = "...", ...)` call in a
+// BUILD.bazel file, along with enough positional information to replace
+// just its `deps` attribute in place.
+type Rule struct {
+	Kind string
+	Name string
+	// startLine/endLine delimit the rule's call, inclusive, 0-indexed.
+	startLine, endLine int
+	// depsStartLine/depsEndLine delimit an existing `deps = [...]` attribute
+	// within the rule, or (-1, -1) if the rule has none yet.
+	depsStartLine, depsEndLine int
+}
+
+// File is a buildozer-style lightweight parse of a BUILD.bazel file: it
+// keeps the original lines untouched and only records where each rule's
+// `deps` attribute lives, so that editing deps never disturbs unrelated
+// formatting or comments.
+type File struct {
+	lines []string
+	Rules []Rule
+}
+
+// ParseBuildFile does a line-oriented parse of a BUILD.bazel file, enough
+// to locate each rule's `name`, `kind` and `deps` attribute. It does not
+// attempt to understand arbitrary Starlark expressions; anything it
+// doesn't recognize is left untouched, which is what lets SetDeps edit a
+// single attribute without reformatting the rest of the file.
+func ParseBuildFile(content string) *File {
+	lines := strings.Split(content, "\n")
+	f := &File{lines: lines}
+
+	ruleHeader := -1
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if ruleHeader == -1 && isRuleHeader(trimmed) {
+			ruleHeader = i
+			continue
+		}
+		if ruleHeader == -1 {
+			continue
+		}
+		if name := attrStringValue(trimmed, "name"); name != "" {
+			kind := strings.TrimSpace(strings.SplitN(strings.TrimSpace(lines[ruleHeader]), "(", 2)[0])
+			f.Rules = append(f.Rules, Rule{Kind: kind, Name: name, startLine: ruleHeader, depsStartLine: -1, depsEndLine: -1})
+		}
+		if strings.HasPrefix(trimmed, "deps") && strings.Contains(trimmed, "=") {
+			start := i
+			end := i
+			if !strings.Contains(trimmed, "]") {
+				for end < len(lines) && !strings.Contains(lines[end], "]") {
+					end++
+				}
+			}
+			if len(f.Rules) > 0 {
+				f.Rules[len(f.Rules)-1].depsStartLine = start
+				f.Rules[len(f.Rules)-1].depsEndLine = end
+			}
+		}
+		if trimmed == ")" {
+			if len(f.Rules) > 0 {
+				f.Rules[len(f.Rules)-1].endLine = i
+			}
+			ruleHeader = -1
+		}
+	}
+	return f
+}
+
+func isRuleHeader(trimmed string) bool {
+	if !strings.Contains(trimmed, "(") {
+		return false
+	}
+	kind := strings.TrimSpace(strings.SplitN(trimmed, "(", 2)[0])
+	return strings.HasSuffix(kind, "_library") || strings.HasSuffix(kind, "_binary") || strings.HasSuffix(kind, "_test")
+}
+
+// attrStringValue returns the value of a `key = "value"` line, or "" if the
+// line isn't an assignment to that key.
+func attrStringValue(trimmed, key string) string {
+	prefix := key + " ="
+	if !strings.HasPrefix(trimmed, prefix) {
+		return ""
+	}
+	value := strings.TrimSpace(strings.TrimPrefix(trimmed, prefix))
+	value = strings.TrimSuffix(value, ",")
+	return strings.Trim(value, `"`)
+}
+
+// FindRule returns the rule matching name, if any.
+func (f *File) FindRule(name string) (Rule, bool) {
+	for _, r := range f.Rules {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+// SetDeps rewrites the `deps = [...]` attribute of the named rule to the
+// given, sorted label list, leaving every other line untouched. If the
+// rule doesn't exist yet, a new rule block is appended at the end of the
+// file. It returns the new file content.
+func (f *File) SetDeps(kind, name string, deps []string) string {
+	sorted := append([]string(nil), deps...)
+	sort.Strings(sorted)
+
+	rule, ok := f.FindRule(name)
+	depsBlock := renderDeps(sorted)
+
+	lines := append([]string(nil), f.lines...)
+	if !ok {
+		block := []string{
+			fmt.Sprintf("%s(", kind),
+			fmt.Sprintf("    name = %q,", name),
+		}
+		block = append(block, depsBlock...)
+		block = append(block, ")")
+		if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+			lines = append(lines, "")
+		}
+		return strings.Join(append(lines, block...), "\n")
+	}
+
+	if rule.depsStartLine >= 0 {
+		result := append([]string(nil), lines[:rule.depsStartLine]...)
+		result = append(result, depsBlock...)
+		result = append(result, lines[rule.depsEndLine+1:]...)
+		return strings.Join(result, "\n")
+	}
+
+	// no existing deps attribute: insert one right before the rule's closing paren.
+	result := append([]string(nil), lines[:rule.endLine]...)
+	result = append(result, depsBlock...)
+	result = append(result, lines[rule.endLine:]...)
+	return strings.Join(result, "\n")
+}
+
+func renderDeps(deps []string) []string {
+	if len(deps) == 0 {
+		return []string{"    deps = [],"}
+	}
+	block := []string{"    deps = ["}
+	for _, d := range deps {
+		block = append(block, fmt.Sprintf("        %q,", d))
+	}
+	block = append(block, "    ],")
+	return block
+}
@@ -0,0 +1,100 @@
+package classify
+
+import "math"
+
+// epsilon keeps log(freq+epsilon) finite for tokens that were never seen
+// for a given language during training.
+const epsilon = 1e-6
+
+// extensionPriorWeight is how many log-points an already-matched extension
+// is worth relative to a single token hit. Extensions are a strong but not
+// absolute signal: a `.h` file really could be C, C++, or Objective-C, so we
+// still let the content pass break the tie.
+const extensionPriorWeight = 5.0
+
+// LanguageClassifier scores a file's content against a set of candidate
+// languages. candidates maps a language name to its current prior score
+// (typically coming from extension matching), and the returned map holds
+// the combined score for every language that was considered.
+type LanguageClassifier interface {
+	Classify(content []byte, candidates map[string]float64) map[string]float64
+}
+
+// TokenFrequencies is a per-language table of how often each token was seen
+// across a training corpus, plus the total token count it was built from.
+// It's the sufficient statistic a naive-Bayes classifier needs.
+type TokenFrequencies struct {
+	Counts map[string]float64
+	Total  float64
+}
+
+// NaiveBayesClassifier implements LanguageClassifier using a classic
+// multinomial naive-Bayes log-likelihood: for a tokenized file, the score
+// for a language L is
+//
+//	score(L) = (Σ_t count(t) * log(freq(t,L) + ε) - log(total_tokens(L) + 1)) / n
+//
+// where n is the number of tokens in the classified file, so the result
+// doesn't grow with file size. Extension-based candidates are folded in as an additive prior
+// rather than a hard filter, so a strong content signal can still win out
+// for files whose extension match was weak or absent.
+type NaiveBayesClassifier struct {
+	Tables map[string]TokenFrequencies
+}
+
+// NewNaiveBayesClassifier builds a classifier out of precomputed
+// per-language token-frequency tables, such as the ones in tables.go.
+func NewNaiveBayesClassifier(tables map[string]TokenFrequencies) *NaiveBayesClassifier {
+	return &NaiveBayesClassifier{Tables: tables}
+}
+
+func (c *NaiveBayesClassifier) Classify(content []byte, candidates map[string]float64) map[string]float64 {
+	tokens := Tokenize(content)
+	counts := map[string]float64{}
+	for _, tok := range tokens {
+		counts[tok]++
+	}
+
+	scores := make(map[string]float64, len(c.Tables))
+	for lang, table := range c.Tables {
+		var score float64
+		for tok, n := range counts {
+			score += n * math.Log(table.Counts[tok]+epsilon)
+		}
+		score -= math.Log(table.Total + 1)
+		// Normalize by token count so this stays a per-token average
+		// log-likelihood instead of a sum that grows with file size: that
+		// keeps it on the same order of magnitude as extensionPriorWeight
+		// regardless of how long the file being classified is.
+		if len(tokens) > 0 {
+			score /= float64(len(tokens))
+		}
+		if prior, ok := candidates[lang]; ok {
+			score += prior * extensionPriorWeight
+		}
+		scores[lang] = score
+	}
+	// Candidates for which we have no trained table still get to keep their
+	// extension-based prior, so an unrecognized-but-matched extension isn't
+	// discarded just because content scoring has nothing to say about it.
+	for lang, prior := range candidates {
+		if _, ok := scores[lang]; !ok {
+			scores[lang] = prior * extensionPriorWeight
+		}
+	}
+	return scores
+}
+
+// Argmax returns the language with the highest score, and false if scores
+// is empty.
+func Argmax(scores map[string]float64) (string, bool) {
+	var best string
+	var bestScore float64
+	found := false
+	for lang, score := range scores {
+		if !found || score > bestScore {
+			best, bestScore, found = lang, score, true
+		}
+	}
+	return best, found
+}
@@ -0,0 +1,33 @@
+package classify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNaiveBayesClassifier_StrongExtensionMajorityWins(t *testing.T) {
+	a := require.New(t)
+
+	classifier := NewDefaultClassifier()
+	// A strong extension-based majority: ten files already matched as go.
+	candidates := map[string]float64{"golang": 10}
+
+	// A single foreign, extensionless file whose content looks like rust.
+	content := []byte(`fn main() { let mut x = Some(1); match x { None => {}, _ => {} } }`)
+
+	lang, ok := Argmax(classifier.Classify(content, candidates))
+	a.True(ok)
+	a.Equal("golang", lang, "a single foreign file shouldn't flip an established extension majority")
+}
+
+func TestNaiveBayesClassifier_NoCandidatesPicksBestContentMatch(t *testing.T) {
+	a := require.New(t)
+
+	classifier := NewDefaultClassifier()
+	content := []byte(`def foo(self): return self.bar`)
+
+	lang, ok := Argmax(classifier.Classify(content, map[string]float64{}))
+	a.True(ok)
+	a.Equal("python", lang)
+}
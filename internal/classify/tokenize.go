@@ -0,0 +1,91 @@
+package classify
+
+import "unicode"
+
+// Tokenize turns file content into the stream of tokens the naive-Bayes
+// classifier scores against its per-language tables: identifiers, keywords,
+// and punctuation/operators, with string and comment bodies dropped so that
+// arbitrary user data doesn't skew the language signal.
+func Tokenize(content []byte) []string {
+	var tokens []string
+	runes := []rune(string(content))
+	n := len(runes)
+
+	for i := 0; i < n; i++ {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			continue
+		case isStringQuote(r):
+			i = skipString(runes, i, r)
+		case r == '/' && i+1 < n && runes[i+1] == '/':
+			i = skipLineComment(runes, i)
+		case r == '/' && i+1 < n && runes[i+1] == '*':
+			i = skipBlockComment(runes, i)
+		case r == '#':
+			i = skipLineComment(runes, i)
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < n && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:i]))
+			i--
+		case unicode.IsDigit(r):
+			start := i
+			for i < n && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:i]))
+			i--
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			if i+1 < n && twoRuneOperators[string(runes[i:i+2])] {
+				tokens = append(tokens, string(runes[i:i+2]))
+				i++
+				continue
+			}
+			tokens = append(tokens, string(r))
+		}
+	}
+	return tokens
+}
+
+// twoRuneOperators are the operators Tokenize emits as a single token
+// instead of one token per rune.
+var twoRuneOperators = map[string]bool{
+	":=": true, "::": true, "=>": true, "->": true,
+	"==": true, "!=": true, "<=": true, ">=": true, "&&": true, "||": true,
+}
+
+func isStringQuote(r rune) bool {
+	return r == '"' || r == '\'' || r == '`'
+}
+
+func skipString(runes []rune, i int, quote rune) int {
+	n := len(runes)
+	i++
+	for i < n && runes[i] != quote {
+		if runes[i] == '\\' && i+1 < n {
+			i++
+		}
+		i++
+	}
+	return i
+}
+
+func skipLineComment(runes []rune, i int) int {
+	n := len(runes)
+	for i < n && runes[i] != '\n' {
+		i++
+	}
+	return i
+}
+
+func skipBlockComment(runes []rune, i int) int {
+	n := len(runes)
+	i += 2
+	for i+1 < n && !(runes[i] == '*' && runes[i+1] == '/') {
+		i++
+	}
+	return i + 1
+}
@@ -0,0 +1,8 @@
+//go:build coverage
+
+package coverage
+
+// buildTagEnabled is true when the package was built with `-tags coverage`,
+// one of the two ways (alongside DEP_TREE_GRAMMAR_COVERAGE=1) to turn
+// grammar coverage instrumentation on.
+const buildTagEnabled = true
@@ -0,0 +1,5 @@
+//go:build !coverage
+
+package coverage
+
+const buildTagEnabled = false
@@ -0,0 +1,73 @@
+// Package coverage instruments the language grammars' parsers so that a
+// test run can report which AST productions (and which of their optional
+// or repeated sub-forms) actually got exercised. It's off by default: a
+// plain `go test` pays nothing for it. Opt in with the `-coverage` build
+// tag or the DEP_TREE_GRAMMAR_COVERAGE=1 environment variable.
+package coverage
+
+import (
+	"os"
+	"sort"
+	"sync"
+)
+
+// Enabled reports whether grammars should bother calling Hit at all.
+var Enabled = buildTagEnabled || os.Getenv("DEP_TREE_GRAMMAR_COVERAGE") == "1"
+
+type registry struct {
+	mu    sync.Mutex
+	known map[string]bool
+	hits  map[string]int
+}
+
+var global = &registry{known: map[string]bool{}, hits: map[string]int{}}
+
+// Register declares production as a node/sub-form the report should track,
+// whether or not it ever gets hit. Grammars call this for productions that
+// exist but aren't wired up to Hit yet, so they show up in the report's
+// never-hit list instead of being invisible to it.
+func Register(production string) {
+	global.mu.Lock()
+	defer global.mu.Unlock()
+	global.known[production] = true
+}
+
+// Hit records that production was exercised once. It's a no-op unless
+// Enabled is true, so call sites don't need to guard it themselves.
+func Hit(production string) {
+	if !Enabled {
+		return
+	}
+	global.mu.Lock()
+	defer global.mu.Unlock()
+	global.known[production] = true
+	global.hits[production]++
+}
+
+// Reset clears every recorded hit and registration. Exposed for tests that
+// want a clean slate.
+func Reset() {
+	global.mu.Lock()
+	defer global.mu.Unlock()
+	global.known = map[string]bool{}
+	global.hits = map[string]int{}
+}
+
+// Snapshot takes a point-in-time copy of the registry as a Report.
+func Snapshot() Report {
+	global.mu.Lock()
+	defer global.mu.Unlock()
+
+	counts := make(map[string]int, len(global.hits))
+	for k, v := range global.hits {
+		counts[k] = v
+	}
+	var neverHit []string
+	for production := range global.known {
+		if global.hits[production] == 0 {
+			neverHit = append(neverHit, production)
+		}
+	}
+	sort.Strings(neverHit)
+	return Report{Counts: counts, NeverHit: neverHit}
+}
@@ -0,0 +1,130 @@
+package coverage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Report is a single point-in-time view of which grammar productions were
+// exercised by a test run, and how many times.
+type Report struct {
+	Counts   map[string]int `json:"counts"`
+	NeverHit []string       `json:"never_hit"`
+}
+
+// WriteJSON writes the report to path as JSON.
+func (r Report) WriteJSON(path string) error {
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o644)
+}
+
+// WriteMarkdown writes a human-readable summary of the report to path.
+func (r Report) WriteMarkdown(path string) error {
+	var sb strings.Builder
+	sb.WriteString("# Grammar coverage\n\n")
+
+	names := make([]string, 0, len(r.Counts))
+	for name := range r.Counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sb.WriteString("| production | hits |\n")
+	sb.WriteString("| --- | --- |\n")
+	for _, name := range names {
+		fmt.Fprintf(&sb, "| %s | %d |\n", name, r.Counts[name])
+	}
+
+	if len(r.NeverHit) > 0 {
+		sb.WriteString("\n## Never hit\n\n")
+		for _, name := range r.NeverHit {
+			fmt.Fprintf(&sb, "- %s\n", name)
+		}
+	}
+	return os.WriteFile(path, []byte(sb.String()), 0o644)
+}
+
+// MergeReports combines any number of reports into one, summing counts for
+// productions several packages both instrumented and keeping a production on
+// the never-hit list only if none of them ever hit it.
+func MergeReports(reports ...Report) Report {
+	merged := Report{Counts: map[string]int{}}
+	neverHit := map[string]bool{}
+	for _, r := range reports {
+		for name, count := range r.Counts {
+			merged.Counts[name] += count
+		}
+		for _, name := range r.NeverHit {
+			neverHit[name] = true
+		}
+	}
+	for name := range merged.Counts {
+		if merged.Counts[name] > 0 {
+			delete(neverHit, name)
+		}
+	}
+	for name := range neverHit {
+		merged.NeverHit = append(merged.NeverHit, name)
+	}
+	sort.Strings(merged.NeverHit)
+	return merged
+}
+
+// ReadReport loads a previously written coverage.json.
+func ReadReport(path string) (Report, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Report{}, err
+	}
+	var r Report
+	if err := json.Unmarshal(content, &r); err != nil {
+		return Report{}, err
+	}
+	return r, nil
+}
+
+// Diff describes how two reports differ: productions hit for the first
+// time, productions on the never-hit list that weren't on it before
+// (whether they stopped being hit or were newly registered), and count
+// deltas for the rest.
+type Diff struct {
+	NewlyCovered  []string       `json:"newly_covered"`
+	NewlyNeverHit []string       `json:"newly_never_hit"`
+	CountDeltas   map[string]int `json:"count_deltas"`
+}
+
+// CompareReports computes the Diff between a before and after Report.
+func CompareReports(before, after Report) Diff {
+	beforeNeverHit := map[string]bool{}
+	for _, name := range before.NeverHit {
+		beforeNeverHit[name] = true
+	}
+	afterNeverHit := map[string]bool{}
+	for _, name := range after.NeverHit {
+		afterNeverHit[name] = true
+	}
+
+	diff := Diff{CountDeltas: map[string]int{}}
+	for name, count := range after.Counts {
+		if before.Counts[name] == 0 && count > 0 {
+			diff.NewlyCovered = append(diff.NewlyCovered, name)
+		}
+		if delta := count - before.Counts[name]; delta != 0 {
+			diff.CountDeltas[name] = delta
+		}
+	}
+	for name := range afterNeverHit {
+		if !beforeNeverHit[name] {
+			diff.NewlyNeverHit = append(diff.NewlyNeverHit, name)
+		}
+	}
+	sort.Strings(diff.NewlyCovered)
+	sort.Strings(diff.NewlyNeverHit)
+	return diff
+}
@@ -0,0 +1,26 @@
+package coverage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeReports(t *testing.T) {
+	a := require.New(t)
+
+	merged := MergeReports(
+		Report{Counts: map[string]int{"a": 1}, NeverHit: []string{"b"}},
+		Report{Counts: map[string]int{"b": 2, "c": 0}, NeverHit: []string{"c"}},
+	)
+
+	a.Equal(map[string]int{"a": 1, "b": 2, "c": 0}, merged.Counts)
+	a.Equal([]string{"c"}, merged.NeverHit)
+}
+
+func TestMergeReports_NoReports(t *testing.T) {
+	a := require.New(t)
+
+	merged := MergeReports()
+	a.Equal(Report{Counts: map[string]int{}}, merged)
+}
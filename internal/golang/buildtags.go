@@ -0,0 +1,27 @@
+package golang
+
+import (
+	"bytes"
+	"go/build"
+	"io"
+	"strings"
+)
+
+// MatchesBuildConstraints reports whether a file is part of the build for
+// the active GOOS/GOARCH, honoring both the legacy `// +build` lines and
+// the `//go:build` boolean-expression form, as well as `_GOOS`/`_GOARCH`
+// filename suffixes (e.g. `foo_linux.go`, `foo_windows_amd64.go`).
+func MatchesBuildConstraints(dir, fileName string, content []byte) (bool, error) {
+	ctx := build.Default
+	ctx.OpenFile = func(path string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(content)), nil
+	}
+	return ctx.MatchFile(dir, fileName)
+}
+
+// IsTestFile reports whether fileName is a Go test file, i.e. it is not
+// meant to be part of the regular build graph unless tests were explicitly
+// asked for.
+func IsTestFile(fileName string) bool {
+	return strings.HasSuffix(fileName, "_test.go")
+}
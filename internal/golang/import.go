@@ -0,0 +1,57 @@
+package golang
+
+import (
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// Import is a single entry out of a file's `import` declaration, whether it
+// came from a single-line `import "foo"` or from one of the parenthesized
+// entries of a grouped import block.
+type Import struct {
+	// Path is the raw import path, e.g. "fmt" or "github.com/foo/bar".
+	Path string
+	// Alias is the local name given to the import, if any. It is left
+	// empty for plain imports, and set to "_" or "." for blank and dot
+	// imports respectively.
+	Alias string
+}
+
+// Blank reports whether this is a side-effect-only `import _ "pkg"`.
+func (i Import) Blank() bool {
+	return i.Alias == "_"
+}
+
+// Dot reports whether this is a dot `import . "pkg"`.
+func (i Import) Dot() bool {
+	return i.Alias == "."
+}
+
+// ParseImports extracts every import entry out of a Go source file,
+// regardless of whether it is written as a single `import "foo"` statement
+// or as a grouped `import (...)` block. It relies on go/parser in
+// ImportsOnly mode, which already understands aliases, blank imports, and
+// dot imports without any extra bookkeeping on our side.
+func ParseImports(fileName string, content []byte) ([]Import, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, fileName, content, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	imports := make([]Import, 0, len(file.Imports))
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			path = strings.Trim(spec.Path.Value, `"`)
+		}
+		entry := Import{Path: path}
+		if spec.Name != nil {
+			entry.Alias = spec.Name.Name
+		}
+		imports = append(imports, entry)
+	}
+	return imports, nil
+}
@@ -0,0 +1,218 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gabotechs/dep-tree/internal/language"
+)
+
+// Extensions are the file extensions that get routed to this language.
+var Extensions = []string{".go"}
+
+// Config holds the knobs that control how Go's import graph gets built.
+type Config struct {
+	// ExcludeStdlib, when true, leaves out standard library packages from the graph.
+	ExcludeStdlib bool
+	// ExcludeThirdParty, when true, leaves out packages that live outside the
+	// current module (resolved from go.mod) from the graph.
+	ExcludeThirdParty bool
+	// IncludeTests, when true, also parses `_test.go` files.
+	IncludeTests bool
+}
+
+// Language implements language.Language for Go source files, building the
+// import graph out of `import` declarations while honoring module
+// boundaries and build constraints.
+type Language struct {
+	cfg *Config
+	mod *Module
+}
+
+var _ language.Language = &Language{}
+
+// MakeGoLanguage builds a Go Language out of the provided Config. It looks
+// up the nearest go.mod starting at the analyzed files' common directory,
+// not the process's cwd, so that module-local imports are told apart from
+// third-party ones correctly even when dep-tree runs against a Go tree it
+// isn't rooted in.
+func MakeGoLanguage(cfg *Config, files []string) (language.Language, error) {
+	mod, err := LoadModule(commonDir(files))
+	if err != nil {
+		return nil, err
+	}
+	return &Language{cfg: cfg, mod: mod}, nil
+}
+
+// commonDir returns the deepest directory shared by every path in files,
+// falling back to "." when files is empty.
+func commonDir(files []string) string {
+	if len(files) == 0 {
+		return "."
+	}
+	common := filepath.ToSlash(filepath.Dir(files[0]))
+	for _, f := range files[1:] {
+		common = commonPrefix(common, filepath.ToSlash(filepath.Dir(f)))
+	}
+	return filepath.FromSlash(common)
+}
+
+// commonPrefix returns the deepest directory shared by two slash-separated
+// directories.
+func commonPrefix(a, b string) string {
+	aParts := strings.Split(a, "/")
+	bParts := strings.Split(b, "/")
+	n := len(aParts)
+	if len(bParts) < n {
+		n = len(bParts)
+	}
+	i := 0
+	for i < n && aParts[i] == bParts[i] {
+		i++
+	}
+	if i == 0 {
+		return "."
+	}
+	common := strings.Join(aParts[:i], "/")
+	if common == "" {
+		// two absolute directories that only share the filesystem root.
+		return "/"
+	}
+	return common
+}
+
+// Node is a single parsed Go source file.
+type Node struct {
+	Path    string
+	Content []byte
+	// Skip is set when the file was excluded from the graph, either because
+	// it's a `_test.go` file and Config.IncludeTests is false, or because it
+	// doesn't match the active GOOS/GOARCH build constraints.
+	Skip bool
+}
+
+// ParseFile reads path off disk and records whether it should actually be
+// part of the graph, per ShouldParseFile.
+func (l *Language) ParseFile(path string) (*Node, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	shouldParse, err := l.ShouldParseFile(filepath.Dir(path), filepath.Base(path), content)
+	if err != nil {
+		return nil, err
+	}
+	return &Node{Path: path, Content: content, Skip: !shouldParse}, nil
+}
+
+// ParseImports extracts file's imports, drops the ones Config says to
+// exclude, and resolves what's left into either the absolute paths of every
+// parseable file in the imported package (module-local imports) or the bare
+// import path (stdlib and third-party imports, which don't correspond to a
+// file in this repo's graph).
+func (l *Language) ParseImports(file *Node) ([]string, error) {
+	if file.Skip {
+		return nil, nil
+	}
+	imports, err := ParseImports(file.Path, file.Content)
+	if err != nil {
+		return nil, err
+	}
+	imports = l.FilterImports(imports)
+
+	var ids []string
+	for _, imp := range imports {
+		resolved, err := l.resolveImportIDs(imp)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, resolved...)
+	}
+	return ids, nil
+}
+
+// ParseExports always returns an empty result: unlike JS's re-exports,
+// visibility of a Go identifier outside of its package is decided by
+// capitalization, not by a per-file list of exported names, so there's
+// nothing for this language to report here.
+func (l *Language) ParseExports(_ *Node) ([]string, error) {
+	return nil, nil
+}
+
+// resolveImportIDs turns a parsed Import into the ids ParseImports reports.
+// The rest of dep-tree keys graph nodes on individual files, and a Go
+// import path names a whole package directory rather than one file, so a
+// module-local import resolves to the absolute path of every file in that
+// package directory ShouldParseFile would also keep, which is exactly what
+// lets the edge attach to those files' parsed nodes. Anything else (stdlib
+// and third-party imports) resolves to the bare import path, which doesn't
+// correspond to a file in this repo's graph.
+func (l *Language) resolveImportIDs(imp Import) ([]string, error) {
+	if l.mod == nil || l.mod.Path == "" || l.mod.Classify(imp.Path) != LocalImport {
+		return []string{imp.Path}, nil
+	}
+	rel := strings.TrimPrefix(imp.Path, l.mod.Path)
+	rel = strings.TrimPrefix(rel, "/")
+	dir := filepath.Join(l.mod.Dir, filepath.FromSlash(rel))
+	return l.packageFiles(dir)
+}
+
+// packageFiles lists the absolute paths of every `.go` file in dir that
+// ShouldParseFile would keep: test files unless Config.IncludeTests is set,
+// and files that match the active GOOS/GOARCH build constraints.
+func (l *Language) packageFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		shouldParse, err := l.ShouldParseFile(dir, entry.Name(), content)
+		if err != nil {
+			return nil, err
+		}
+		if shouldParse {
+			files = append(files, path)
+		}
+	}
+	return files, nil
+}
+
+// ShouldParseFile reports whether a file belongs in the graph at all: test
+// files are skipped unless Config.IncludeTests is set, and files that don't
+// match the active GOOS/GOARCH build constraints are always skipped.
+func (l *Language) ShouldParseFile(dir, fileName string, content []byte) (bool, error) {
+	if IsTestFile(fileName) && !l.cfg.IncludeTests {
+		return false, nil
+	}
+	return MatchesBuildConstraints(dir, fileName, content)
+}
+
+// FilterImports drops stdlib and/or third-party imports according to
+// Config, leaving the rest untouched.
+func (l *Language) FilterImports(imports []Import) []Import {
+	filtered := make([]Import, 0, len(imports))
+	for _, imp := range imports {
+		switch l.mod.Classify(imp.Path) {
+		case StdlibImport:
+			if l.cfg.ExcludeStdlib {
+				continue
+			}
+		case ThirdPartyImport:
+			if l.cfg.ExcludeThirdParty {
+				continue
+			}
+		}
+		filtered = append(filtered, imp)
+	}
+	return filtered
+}
@@ -0,0 +1,147 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImports_GroupedBlankDotAlias(t *testing.T) {
+	a := require.New(t)
+
+	content := []byte(`package foo
+
+import (
+	"fmt"
+	_ "embed"
+	. "strings"
+	alias "os/exec"
+)
+`)
+	imports, err := ParseImports("foo.go", content)
+	a.NoError(err)
+	a.Equal([]Import{
+		{Path: "fmt"},
+		{Path: "embed", Alias: "_"},
+		{Path: "strings", Alias: "."},
+		{Path: "os/exec", Alias: "alias"},
+	}, imports)
+
+	a.False(imports[0].Blank())
+	a.True(imports[1].Blank())
+	a.True(imports[2].Dot())
+}
+
+func TestLanguage_ShouldParseFile_SkipsNonMatchingBuildTag(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+
+	otherGOOS := "windows"
+	if runtime.GOOS == "windows" {
+		otherGOOS = "linux"
+	}
+	content := []byte("//go:build " + otherGOOS + "\n\npackage foo\n")
+
+	lang := &Language{cfg: &Config{}, mod: &Module{}}
+	should, err := lang.ShouldParseFile(dir, "foo.go", content)
+	a.NoError(err)
+	a.False(should)
+
+	node, err := lang.ParseFile(writeFile(t, dir, "foo.go", content))
+	a.NoError(err)
+	a.True(node.Skip)
+
+	imports, err := lang.ParseImports(node)
+	a.NoError(err)
+	a.Empty(imports)
+}
+
+func TestLanguage_ShouldParseFile_SkipsTestFilesByDefault(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+
+	lang := &Language{cfg: &Config{}, mod: &Module{}}
+	should, err := lang.ShouldParseFile(dir, "foo_test.go", []byte("package foo\n"))
+	a.NoError(err)
+	a.False(should)
+
+	lang.cfg.IncludeTests = true
+	should, err = lang.ShouldParseFile(dir, "foo_test.go", []byte("package foo\n"))
+	a.NoError(err)
+	a.True(should)
+}
+
+func TestLanguage_FilterImports(t *testing.T) {
+	a := require.New(t)
+	lang := &Language{
+		cfg: &Config{ExcludeStdlib: true},
+		mod: &Module{Path: "example.com/mod"},
+	}
+	filtered := lang.FilterImports([]Import{
+		{Path: "fmt"},
+		{Path: "example.com/mod/sub"},
+		{Path: "github.com/foo/bar"},
+	})
+	a.Equal([]Import{
+		{Path: "example.com/mod/sub"},
+		{Path: "github.com/foo/bar"},
+	}, filtered)
+}
+
+func TestLanguage_ParseImports_ResolvesLocalImportToPackageFiles(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	writeFile(t, dir, "go.mod", []byte("module example.com/mod\n\ngo 1.21\n"))
+
+	subDir := filepath.Join(dir, "sub")
+	a.NoError(os.MkdirAll(subDir, 0o755))
+	subFile := writeFile(t, subDir, "sub.go", []byte("package sub\n"))
+	writeFile(t, subDir, "sub_test.go", []byte("package sub\n"))
+
+	mainPath := writeFile(t, dir, "main.go", []byte(`package main
+
+import "example.com/mod/sub"
+`))
+
+	mod, err := LoadModule(dir)
+	a.NoError(err)
+	lang := &Language{cfg: &Config{}, mod: mod}
+
+	node, err := lang.ParseFile(mainPath)
+	a.NoError(err)
+	ids, err := lang.ParseImports(node)
+	a.NoError(err)
+	a.Equal([]string{subFile}, ids, "sub_test.go is excluded since IncludeTests defaults to false")
+}
+
+func TestMakeGoLanguage_ResolvesModuleFromFilesNotCwd(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	writeFile(t, dir, "go.mod", []byte("module example.com/mod\n\ngo 1.21\n"))
+
+	subDir := filepath.Join(dir, "sub")
+	a.NoError(os.MkdirAll(subDir, 0o755))
+	file := writeFile(t, subDir, "foo.go", []byte("package sub\n"))
+
+	lang, err := MakeGoLanguage(&Config{}, []string{file})
+	a.NoError(err)
+	a.Equal(dir, lang.(*Language).mod.Dir)
+	a.Equal("example.com/mod", lang.(*Language).mod.Path)
+}
+
+func TestCommonDir(t *testing.T) {
+	a := require.New(t)
+	a.Equal(".", commonDir(nil))
+	a.Equal(filepath.FromSlash("a/b"), commonDir([]string{"a/b/c.go", "a/b/d/e.go"}))
+	a.Equal(filepath.FromSlash("a"), commonDir([]string{"a/b/c.go", "a/x/y.go"}))
+}
+
+func writeFile(t *testing.T, dir, name string, content []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, content, 0o644))
+	return path
+}
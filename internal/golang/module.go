@@ -0,0 +1,94 @@
+package golang
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Module carries the bits of go.mod that matter for import resolution: the
+// module's own path, so that imports rooted at it can be recognized as
+// module-local rather than third-party.
+type Module struct {
+	// Path is the module path declared in the `module` directive of go.mod.
+	Path string
+	// Dir is the directory that holds go.mod.
+	Dir string
+}
+
+// LoadModule walks up from dir looking for the nearest go.mod and parses its
+// `module` directive. It returns a zero-value Module (Path == "") without an
+// error when no go.mod can be found, since dep-tree should still be able to
+// build a graph for a directory that isn't part of a Go module.
+func LoadModule(dir string) (*Module, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for current := abs; ; {
+		goModPath := filepath.Join(current, "go.mod")
+		if path, err := readModulePath(goModPath); err == nil {
+			return &Module{Path: path, Dir: current}, nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return &Module{}, nil
+		}
+		current = parent
+	}
+}
+
+func readModulePath(goModPath string) (string, error) {
+	f, err := os.Open(goModPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module")), nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+// ImportKind classifies a resolved import path relative to a Module.
+type ImportKind int
+
+const (
+	StdlibImport ImportKind = iota
+	LocalImport
+	ThirdPartyImport
+)
+
+// Classify tells apart standard library, module-local, and third-party
+// import paths. An import path is considered module-local when it is the
+// module's own path or a sub-package of it.
+func (m *Module) Classify(importPath string) ImportKind {
+	if m != nil && m.Path != "" && (importPath == m.Path || strings.HasPrefix(importPath, m.Path+"/")) {
+		return LocalImport
+	}
+	if isStdlib(importPath) {
+		return StdlibImport
+	}
+	return ThirdPartyImport
+}
+
+// isStdlib reports whether importPath looks like a standard library
+// package: it has no dot in its first path segment, which is the same
+// heuristic `go list` and friends rely on to tell stdlib apart from
+// module paths such as `github.com/foo/bar`.
+func isStdlib(importPath string) bool {
+	first := importPath
+	if idx := strings.IndexByte(importPath, '/'); idx >= 0 {
+		first = importPath[:idx]
+	}
+	return !strings.Contains(first, ".")
+}
@@ -0,0 +1,172 @@
+package python_grammar
+
+import (
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+
+	"github.com/gabotechs/dep-tree/internal/coverage"
+)
+
+// ImportedName is a single `name` or `name as alias` entry out of a
+// `from ... import (...)` list.
+type ImportedName struct {
+	Name  string `@Ident`
+	Alias string `("as" @Ident)?`
+}
+
+// Import is a plain `import foo.bar as alias` statement.
+type Import struct {
+	Path  []string `"import" @Ident ("." @Ident)*`
+	Alias string   `("as" @Ident)?`
+
+	Indented bool `parser:"-"`
+
+	// TypeCheckingOnly is set when this import only runs under a
+	// `if TYPE_CHECKING:` guard, so it's never actually executed at runtime.
+	TypeCheckingOnly bool `parser:"-"`
+	// TryGuarded is set when this import sits inside a
+	// `try: ... except ImportError: ...` block.
+	TryGuarded bool `parser:"-"`
+	// IfGuard holds the guard expression of the nearest enclosing bare `if`
+	// block this import sits in, e.g. `sys.version_info >= (3, 8)`. It's
+	// empty when the import isn't `if`-guarded (TYPE_CHECKING guards set
+	// TypeCheckingOnly instead, and don't populate IfGuard).
+	IfGuard string `parser:"-"`
+	// Scope tells apart module-level imports from lazy ones tucked inside a
+	// function or class body.
+	Scope Scope `parser:"-"`
+}
+
+// FromImport is a `from .foo import a, b as c` / `from foo import *`
+// statement. Relative holds one entry per leading dot in the `from` clause,
+// so `from ...pkg import x` has `Relative == []bool{true, true, true}`.
+type FromImport struct {
+	Relative []bool         `"from" ( @"."+ )?`
+	Path     []string       `( @Ident ("." @Ident)* )?`
+	All      bool           `"import" ( @"*"`
+	Names    []ImportedName `  | "(" @@ ("," @@)* ","? ")" | @@ ("," @@)* )`
+
+	Indented bool `parser:"-"`
+
+	TypeCheckingOnly bool   `parser:"-"`
+	TryGuarded       bool   `parser:"-"`
+	IfGuard          string `parser:"-"`
+	Scope            Scope  `parser:"-"`
+}
+
+// statement is one logical top-level construct in a Python source file.
+// Anything that isn't an import or a from-import is swallowed token by
+// token by Skip, so that the parser never fails on the rest of the
+// language it doesn't otherwise care about.
+type statement struct {
+	Pos lexer.Position
+
+	Import     *Import     `(  @@`
+	FromImport *FromImport `   | @@`
+	Skip       *string     `   | @( Ident | Dot | Comma | LParen | RParen | Star | Any ) )`
+}
+
+// file is the parser's root node: a Python source file as a flat sequence
+// of statements.
+type file struct {
+	Statements []*statement `@@*`
+}
+
+var pythonLexer = lexer.MustSimple([]lexer.SimpleRule{
+	{Name: "Ident", Pattern: `[a-zA-Z_][a-zA-Z0-9_]*`},
+	{Name: "Dot", Pattern: `\.`},
+	{Name: "Comma", Pattern: `,`},
+	{Name: "LParen", Pattern: `\(`},
+	{Name: "RParen", Pattern: `\)`},
+	{Name: "Star", Pattern: `\*`},
+	{Name: "Whitespace", Pattern: `[ \t]+`},
+	{Name: "Newline", Pattern: `\r?\n`},
+	{Name: "Any", Pattern: `.`},
+})
+
+var rawParser = participle.MustBuild[file](
+	participle.Lexer(pythonLexer),
+	participle.Elide("Whitespace", "Newline"),
+	participle.UseLookahead(2),
+)
+
+// Parsed is the result of parsing a Python source file: every statement
+// found in it, in source order.
+type Parsed struct {
+	Statements []*statement
+}
+
+type pythonParser struct{}
+
+// parser exposes the entry point the rest of this package (and its tests)
+// use to turn Python source into the import statements it contains.
+var parser = pythonParser{}
+
+// ParseBytes parses content as a Python source file, returning one
+// statement per top-level construct. Import and FromImport statements have
+// their Indented flag and scope metadata (TypeCheckingOnly, TryGuarded,
+// IfGuard, Scope) set based on where they were found in content.
+func (pythonParser) ParseBytes(filename string, content []byte) (*Parsed, error) {
+	parsed, err := rawParser.ParseBytes(filename, content)
+	if err != nil {
+		return nil, err
+	}
+	for _, stmt := range parsed.Statements {
+		indented := stmt.Pos.Column > 1
+		scope := scopeAt(content, stmt.Pos.Line)
+		switch {
+		case stmt.Import != nil:
+			stmt.Import.Indented = indented
+			stmt.Import.TypeCheckingOnly = scope.typeChecking
+			stmt.Import.TryGuarded = scope.tryGuarded
+			stmt.Import.IfGuard = scope.ifGuard
+			stmt.Import.Scope = scope.scope
+			recordImportCoverage("python.Import", indented, scope)
+		case stmt.FromImport != nil:
+			stmt.FromImport.Indented = indented
+			stmt.FromImport.TypeCheckingOnly = scope.typeChecking
+			stmt.FromImport.TryGuarded = scope.tryGuarded
+			stmt.FromImport.IfGuard = scope.ifGuard
+			stmt.FromImport.Scope = scope.scope
+			recordImportCoverage("python.FromImport", indented, scope)
+			if stmt.FromImport.All {
+				coverage.Hit("python.FromImport.All")
+			}
+			if len(stmt.FromImport.Relative) > 0 {
+				coverage.Hit("python.FromImport.Relative")
+			}
+		}
+	}
+	return &Parsed{Statements: parsed.Statements}, nil
+}
+
+// recordImportCoverage hits the sub-forms shared by Import and FromImport:
+// whether it was indented, and which guard/scope it was found under.
+func recordImportCoverage(node string, indented bool, scope scopeResult) {
+	coverage.Hit(node)
+	if indented {
+		coverage.Hit(node + ".Indented")
+	}
+	if scope.typeChecking {
+		coverage.Hit(node + ".TypeCheckingOnly")
+	}
+	if scope.tryGuarded {
+		coverage.Hit(node + ".TryGuarded")
+	}
+	if scope.ifGuard != "" {
+		coverage.Hit(node + ".IfGuard")
+	}
+	switch scope.scope {
+	case FunctionScope:
+		coverage.Hit(node + ".Scope.Function")
+	case ClassScope:
+		coverage.Hit(node + ".Scope.Class")
+	}
+}
+
+func init() {
+	// Known productions this grammar doesn't implement yet, so the coverage
+	// report can flag them as never-hit instead of being silent about them.
+	coverage.Register("python.TypeStatement")   // PEP-695 `type X = ...`
+	coverage.Register("python.WalrusInIfGuard") // `if (n := f()):` guards
+}
@@ -7,8 +7,23 @@ import (
 	"path"
 	"strings"
 	"testing"
+
+	"github.com/gabotechs/dep-tree/internal/coverage"
 )
 
+// TestMain lets this package's tests flush a grammar coverage report once
+// they're all done, when DEP_TREE_GRAMMAR_COVERAGE=1 or the `coverage`
+// build tag turned instrumentation on.
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if coverage.Enabled {
+		report := coverage.Snapshot()
+		_ = report.WriteJSON("coverage.json")
+		_ = report.WriteMarkdown("coverage.md")
+	}
+	os.Exit(code)
+}
+
 func TestImport(t *testing.T) {
 	tests := []struct {
 		Name                string
@@ -131,6 +146,21 @@ func TestImport(t *testing.T) {
 			Name:                "from foo import (a as a_2,\n  b)",
 			ExpectedFromImports: []FromImport{{Names: []ImportedName{{Name: "a", Alias: "a_2"}, {Name: "b"}}, Path: []string{"foo"}}},
 		},
+		{
+			Name:            "if TYPE_CHECKING:\n    import foo",
+			ExpectedImports: []Import{{Path: []string{"foo"}, Indented: true, TypeCheckingOnly: true}},
+		},
+		{
+			Name: "try:\n    import foo\nexcept ImportError:\n    import bar",
+			ExpectedImports: []Import{
+				{Path: []string{"foo"}, Indented: true, TryGuarded: true},
+				{Path: []string{"bar"}, Indented: true, TryGuarded: true},
+			},
+		},
+		{
+			Name:            "def f():\n    import foo",
+			ExpectedImports: []Import{{Path: []string{"foo"}, Indented: true, Scope: FunctionScope}},
+		},
 	}
 
 	for _, tt := range tests {
@@ -164,4 +194,4 @@ func TestImport(t *testing.T) {
 			a.Equal(tt.ExpectedFromImports, fromImports)
 		})
 	}
-}
\ No newline at end of file
+}
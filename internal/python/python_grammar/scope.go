@@ -0,0 +1,134 @@
+package python_grammar
+
+import (
+	"strings"
+)
+
+// Scope tells apart a module-level import from one that only runs once a
+// function or class body executes.
+type Scope int
+
+const (
+	ModuleScope Scope = iota
+	FunctionScope
+	ClassScope
+)
+
+// guardKind identifies which kind of block header opened an indentation
+// frame while scanning for the scope an import sits in.
+type guardKind int
+
+const (
+	guardTypeChecking guardKind = iota
+	guardTry
+	guardExcept
+	guardIf
+	guardFunction
+	guardClass
+)
+
+type guardFrame struct {
+	indent int
+	kind   guardKind
+	expr   string
+}
+
+type scopeResult struct {
+	typeChecking bool
+	tryGuarded   bool
+	ifGuard      string
+	scope        Scope
+}
+
+// scopeAt does a lightweight indentation-based scan of content's lines
+// leading up to (and including) line, reconstructing the stack of
+// `if TYPE_CHECKING:`, `try:`/`except ImportError:`, bare `if:`, `def`, and
+// `class` blocks the statement at line is nested under. It deliberately
+// doesn't build a full AST: indentation plus a handful of header patterns
+// is enough to classify an import the way this package needs to.
+func scopeAt(content []byte, line int) scopeResult {
+	lines := strings.Split(string(content), "\n")
+	if line < 1 || line > len(lines) {
+		return scopeResult{}
+	}
+
+	var stack []guardFrame
+	targetIndent := indentOf(lines[line-1])
+
+	for i := 0; i < line-1; i++ {
+		raw := lines[i]
+		trimmed := strings.TrimSpace(raw)
+		if trimmed == "" {
+			continue
+		}
+		indent := indentOf(raw)
+		for len(stack) > 0 && indent <= stack[len(stack)-1].indent {
+			stack = stack[:len(stack)-1]
+		}
+		if frame, ok := matchGuardHeader(trimmed, indent); ok {
+			stack = append(stack, frame)
+		}
+	}
+	for len(stack) > 0 && targetIndent <= stack[len(stack)-1].indent {
+		stack = stack[:len(stack)-1]
+	}
+
+	var result scopeResult
+	result.scope = ModuleScope
+	for _, frame := range stack {
+		switch frame.kind {
+		case guardTypeChecking:
+			result.typeChecking = true
+		case guardTry, guardExcept:
+			result.tryGuarded = true
+		case guardIf:
+			// frames run outermost to innermost, so the nearest `if` wins.
+			result.ifGuard = frame.expr
+		case guardFunction:
+			result.scope = FunctionScope
+		case guardClass:
+			result.scope = ClassScope
+		}
+	}
+	return result
+}
+
+func indentOf(line string) int {
+	n := 0
+	for _, r := range line {
+		if r == ' ' {
+			n++
+		} else if r == '\t' {
+			n += 8
+		} else {
+			break
+		}
+	}
+	return n
+}
+
+// matchGuardHeader reports whether trimmed is a block header this package
+// cares about, returning the guardFrame it opens.
+func matchGuardHeader(trimmed string, indent int) (guardFrame, bool) {
+	if !strings.HasSuffix(trimmed, ":") {
+		return guardFrame{}, false
+	}
+	body := strings.TrimSuffix(trimmed, ":")
+
+	switch {
+	case body == "if TYPE_CHECKING" || strings.HasPrefix(body, "if TYPE_CHECKING "):
+		return guardFrame{indent: indent, kind: guardTypeChecking}, true
+	case body == "try":
+		return guardFrame{indent: indent, kind: guardTry}, true
+	case strings.HasPrefix(body, "except") && strings.Contains(body, "ImportError"):
+		return guardFrame{indent: indent, kind: guardExcept}, true
+	case strings.HasPrefix(body, "if "):
+		return guardFrame{indent: indent, kind: guardIf, expr: strings.TrimSpace(strings.TrimPrefix(body, "if"))}, true
+	case strings.HasPrefix(body, "def ") || strings.HasPrefix(body, "async def "):
+		return guardFrame{indent: indent, kind: guardFunction}, true
+	case strings.HasPrefix(body, "class "):
+		return guardFrame{indent: indent, kind: guardClass}, true
+	default:
+		return guardFrame{}, false
+	}
+}